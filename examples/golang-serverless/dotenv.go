@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// dotEnvEnabled reports whether .env loading has been requested via
+// MUSKETEERS_DOTENV. Production Lambda invocations leave this unset, so
+// loadDotEnv is never consulted outside local development.
+func dotEnvEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("MUSKETEERS_DOTENV"))
+	return enabled
+}
+
+// loadDotEnv parses a gotenv-style .env file at path, populating any
+// environment variable that is not already set. Variables the Lambda
+// runtime has already provided always win over the file.
+func loadDotEnv(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = unquoteDotEnvValue(strings.TrimSpace(value))
+
+		if _, present := os.LookupEnv(key); present {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// unquoteDotEnvValue strips a single matching pair of surrounding quotes, if present.
+func unquoteDotEnvValue(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+const echoMessageEnvVarName = "ECHO_MESSAGE"
+
+// Echo is the Lambda entry point. It selects a MessageProvider based on
+// ECHO_MESSAGE_SOURCE and delegates to echo.
+func Echo(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	provider, err := newMessageProvider(ctx)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+	return echo(ctx, request, provider)
+}
+
+// echo renders ECHO_MESSAGE_TEMPLATE against request when set, otherwise it
+// falls back to the message returned by provider. The response honours
+// ECHO_RESPONSE_HEADERS and ECHO_STATUS_CODE overrides.
+func echo(ctx context.Context, request events.APIGatewayProxyRequest, provider MessageProvider) (events.APIGatewayProxyResponse, error) {
+	body, err := echoBody(ctx, request, provider)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode(200),
+		Headers:    responseHeaders(),
+		Body:       body,
+	}, nil
+}
+
+// echoBody computes the response body: the rendered message template when
+// ECHO_MESSAGE_TEMPLATE is set, otherwise the message returned by provider.
+func echoBody(ctx context.Context, request events.APIGatewayProxyRequest, provider MessageProvider) (string, error) {
+	if tmpl := os.Getenv(messageTemplateEnvVarName); tmpl != "" {
+		return renderMessageTemplate(tmpl, request)
+	}
+	return provider.Get(ctx)
+}
+
+func main() {
+	if dotEnvEnabled() {
+		if err := loadDotEnv(".env"); err != nil && !os.IsNotExist(err) {
+			panic(err)
+		}
+	}
+	lambda.Start(Echo)
+}
@@ -1,34 +1,90 @@
 package main
 
 import (
-	"github.com/aws/aws-lambda-go/events"
-	"github.com/stretchr/testify/assert"
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
 )
 
-type configureEnvVar func()
+// fakeMessageProvider is a MessageProvider test double, used in place of
+// os.Setenv so tests can exercise echo without touching real env vars.
+type fakeMessageProvider struct {
+	message string
+	err     error
+}
+
+func (f fakeMessageProvider) Get(ctx context.Context) (string, error) {
+	return f.message, f.err
+}
 
 var echotests = []struct {
-	configureEnvVar configureEnvVar
+	name            string
+	provider        MessageProvider
 	expectedMessage string
+	expectError     bool
 }{
-	{func() { os.Setenv(echoMessageEnvVarName, "Thank you for using the 3 Musketeers!") }, "Thank you for using the 3 Musketeers!"},
-	{func() { os.Setenv(echoMessageEnvVarName, "") }, ""},
-	{func() { os.Unsetenv(echoMessageEnvVarName) }, ""},
+	{"env provider", envMessageProvider{}, "", false},
+	{"fake provider returns message", fakeMessageProvider{message: "Thank you for using the 3 Musketeers!"}, "Thank you for using the 3 Musketeers!", false},
+	{"fake provider returns empty message", fakeMessageProvider{message: ""}, "", false},
+	{"fake provider errors", fakeMessageProvider{err: errors.New("boom")}, "", true},
 }
 
 func TestEcho(t *testing.T) {
+	os.Unsetenv(echoMessageEnvVarName)
+
 	for _, gt := range echotests {
-		// given
-		evt := events.APIGatewayProxyRequest{}
-		gt.configureEnvVar()
+		t.Run(gt.name, func(t *testing.T) {
+			// given
+			evt := events.APIGatewayProxyRequest{}
 
-		// when
-		response, err := Echo(nil, evt)
+			// when
+			response, err := echo(context.Background(), evt, gt.provider)
 
-		// then
-		assert.NoError(t, err)
-		assert.Equal(t, gt.expectedMessage, response.Body)
+			// then
+			if gt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, gt.expectedMessage, response.Body)
+		})
 	}
 }
+
+func TestLoadDotEnv(t *testing.T) {
+	// given
+	os.Unsetenv(echoMessageEnvVarName)
+	dotEnvPath := filepath.Join(t.TempDir(), ".env")
+	contents := "# comment\nexport " + echoMessageEnvVarName + "=\"Thank you for using the 3 Musketeers!\"\n"
+	assert.NoError(t, os.WriteFile(dotEnvPath, []byte(contents), 0o600))
+
+	// when
+	err := loadDotEnv(dotEnvPath)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "Thank you for using the 3 Musketeers!", os.Getenv(echoMessageEnvVarName))
+
+	response, err := Echo(nil, events.APIGatewayProxyRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Thank you for using the 3 Musketeers!", response.Body)
+}
+
+func TestLoadDotEnvDoesNotOverrideExisting(t *testing.T) {
+	// given
+	os.Setenv(echoMessageEnvVarName, "set by Lambda")
+	dotEnvPath := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(dotEnvPath, []byte(echoMessageEnvVarName+"=set by .env\n"), 0o600))
+
+	// when
+	err := loadDotEnv(dotEnvPath)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "set by Lambda", os.Getenv(echoMessageEnvVarName))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+const (
+	messageSourceEnvVarName    = "ECHO_MESSAGE_SOURCE"
+	ssmParameterNameEnvVarName = "ECHO_MESSAGE_SSM_NAME"
+	secretIDEnvVarName         = "ECHO_MESSAGE_SECRET_ID"
+)
+
+// MessageProvider supplies the message Echo returns when
+// ECHO_MESSAGE_TEMPLATE is unset.
+type MessageProvider interface {
+	Get(ctx context.Context) (string, error)
+}
+
+// envMessageProvider reads the message from echoMessageEnvVarName, resolved
+// through resolveMessage. This is the default provider.
+type envMessageProvider struct{}
+
+func (envMessageProvider) Get(ctx context.Context) (string, error) {
+	return resolveMessage(os.Getenv(echoMessageEnvVarName))
+}
+
+// ssmGetParameterAPI is the subset of the SSM client Get uses, so tests can
+// supply a mock instead of talking to AWS.
+type ssmGetParameterAPI interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// ssmMessageProvider reads the message from an AWS SSM parameter.
+type ssmMessageProvider struct {
+	client         ssmGetParameterAPI
+	parameterName  string
+	withDecryption bool
+}
+
+func (p ssmMessageProvider) Get(ctx context.Context) (string, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(p.parameterName),
+		WithDecryption: aws.Bool(p.withDecryption),
+	})
+	if err != nil {
+		return "", fmt.Errorf("ssmMessageProvider: getting parameter %s: %w", p.parameterName, err)
+	}
+	return aws.ToString(out.Parameter.Value), nil
+}
+
+// secretsManagerGetSecretValueAPI is the subset of the Secrets Manager
+// client Get uses, so tests can supply a mock instead of talking to AWS.
+type secretsManagerGetSecretValueAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// secretsManagerMessageProvider reads the message from an AWS Secrets
+// Manager secret.
+type secretsManagerMessageProvider struct {
+	client   secretsManagerGetSecretValueAPI
+	secretID string
+}
+
+func (p secretsManagerMessageProvider) Get(ctx context.Context) (string, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(p.secretID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("secretsManagerMessageProvider: getting secret %s: %w", p.secretID, err)
+	}
+	return aws.ToString(out.SecretString), nil
+}
+
+// newMessageProvider selects a MessageProvider based on ECHO_MESSAGE_SOURCE.
+// It defaults to envMessageProvider when the variable is unset.
+func newMessageProvider(ctx context.Context) (MessageProvider, error) {
+	switch source := os.Getenv(messageSourceEnvVarName); source {
+	case "", "env":
+		return envMessageProvider{}, nil
+	case "ssm":
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("newMessageProvider: loading AWS config: %w", err)
+		}
+		return ssmMessageProvider{
+			client:         ssm.NewFromConfig(cfg),
+			parameterName:  os.Getenv(ssmParameterNameEnvVarName),
+			withDecryption: true,
+		}, nil
+	case "secretsmanager":
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("newMessageProvider: loading AWS config: %w", err)
+		}
+		return secretsManagerMessageProvider{
+			client:   secretsmanager.NewFromConfig(cfg),
+			secretID: os.Getenv(secretIDEnvVarName),
+		}, nil
+	default:
+		return nil, fmt.Errorf("newMessageProvider: unknown %s %q", messageSourceEnvVarName, source)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSSMMessageProviderIntegration talks to the AWS-compatible endpoint at
+// AWS_ENDPOINT_URL (e.g. a localstack container) rather than real AWS.
+func TestSSMMessageProviderIntegration(t *testing.T) {
+	endpoint := os.Getenv("AWS_ENDPOINT_URL")
+	if endpoint == "" {
+		t.Skip("AWS_ENDPOINT_URL not set, skipping integration test")
+	}
+
+	// given
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithBaseEndpoint(endpoint))
+	require.NoError(t, err)
+
+	client := ssm.NewFromConfig(cfg)
+	const parameterName = "/musketeers/echo-message-integration"
+	_, err = client.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(parameterName),
+		Value:     aws.String("Thank you for using the 3 Musketeers!"),
+		Type:      "String",
+		Overwrite: aws.Bool(true),
+	})
+	require.NoError(t, err)
+
+	provider := ssmMessageProvider{client: client, parameterName: parameterName}
+
+	// when
+	message, err := provider.Get(ctx)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "Thank you for using the 3 Musketeers!", message)
+}
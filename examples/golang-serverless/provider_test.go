@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSSMClient struct {
+	output *ssm.GetParameterOutput
+	err    error
+}
+
+func (m mockSSMClient) GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	return m.output, m.err
+}
+
+func TestSSMMessageProvider(t *testing.T) {
+	// given
+	provider := ssmMessageProvider{
+		client:         mockSSMClient{output: &ssm.GetParameterOutput{Parameter: &ssmtypes.Parameter{Value: aws.String("from ssm")}}},
+		parameterName:  "/musketeers/echo-message",
+		withDecryption: true,
+	}
+
+	// when
+	message, err := provider.Get(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "from ssm", message)
+}
+
+func TestSSMMessageProviderError(t *testing.T) {
+	// given
+	provider := ssmMessageProvider{
+		client:        mockSSMClient{err: errors.New("access denied")},
+		parameterName: "/musketeers/echo-message",
+	}
+
+	// when
+	_, err := provider.Get(context.Background())
+
+	// then
+	assert.Error(t, err)
+}
+
+type mockSecretsManagerClient struct {
+	output *secretsmanager.GetSecretValueOutput
+	err    error
+}
+
+func (m mockSecretsManagerClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	return m.output, m.err
+}
+
+func TestSecretsManagerMessageProvider(t *testing.T) {
+	// given
+	provider := secretsManagerMessageProvider{
+		client:   mockSecretsManagerClient{output: &secretsmanager.GetSecretValueOutput{SecretString: aws.String("from secrets manager")}},
+		secretID: "musketeers/echo-message",
+	}
+
+	// when
+	message, err := provider.Get(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "from secrets manager", message)
+}
+
+func TestSecretsManagerMessageProviderError(t *testing.T) {
+	// given
+	provider := secretsManagerMessageProvider{
+		client:   mockSecretsManagerClient{err: errors.New("access denied")},
+		secretID: "musketeers/echo-message",
+	}
+
+	// when
+	_, err := provider.Get(context.Background())
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestNewMessageProviderDefaultsToEnv(t *testing.T) {
+	// given
+	os.Unsetenv(messageSourceEnvVarName)
+
+	// when
+	provider, err := newMessageProvider(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.IsType(t, envMessageProvider{}, provider)
+}
+
+func TestNewMessageProviderUnknownSource(t *testing.T) {
+	// given
+	os.Setenv(messageSourceEnvVarName, "carrier-pigeon")
+	defer os.Unsetenv(messageSourceEnvVarName)
+
+	// when
+	_, err := newMessageProvider(context.Background())
+
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// resolveMessage expands ${NAME} and $NAME references against os.LookupEnv,
+// then honours the file:// and env:// schemes on the fully-resolved string.
+// A literal $$ is treated as an escape for a single $. Missing env vars
+// resolve to an empty string rather than an error.
+func resolveMessage(message string) (string, error) {
+	expanded := expandEnv(message)
+
+	switch {
+	case strings.HasPrefix(expanded, "file://"):
+		path := strings.TrimPrefix(expanded, "file://")
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("resolveMessage: reading %s: %w", path, err)
+		}
+		return strings.TrimSpace(string(contents)), nil
+	case strings.HasPrefix(expanded, "env://"):
+		name := strings.TrimPrefix(expanded, "env://")
+		return os.Getenv(name), nil
+	default:
+		return expanded, nil
+	}
+}
+
+// expandEnv walks message left to right, expanding ${NAME} and $NAME tokens
+// against os.LookupEnv and turning $$ into a single literal $.
+func expandEnv(message string) string {
+	var out strings.Builder
+
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c != '$' || i == len(message)-1 {
+			out.WriteByte(c)
+			continue
+		}
+
+		next := message[i+1]
+		switch {
+		case next == '$':
+			out.WriteByte('$')
+			i++
+		case next == '{':
+			end := strings.IndexByte(message[i+2:], '}')
+			if end == -1 {
+				out.WriteByte(c)
+				continue
+			}
+			name := message[i+2 : i+2+end]
+			out.WriteString(os.Getenv(name))
+			i += 2 + end
+		case isEnvNameByte(next):
+			j := i + 1
+			for j < len(message) && isEnvNameByte(message[j]) {
+				j++
+			}
+			out.WriteString(os.Getenv(message[i+1 : j]))
+			i = j - 1
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return out.String()
+}
+
+func isEnvNameByte(b byte) bool {
+	return b == '_' ||
+		(b >= 'a' && b <= 'z') ||
+		(b >= 'A' && b <= 'Z') ||
+		(b >= '0' && b <= '9')
+}
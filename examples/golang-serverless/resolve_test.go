@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveMessage(t *testing.T) {
+	os.Setenv("MUSKETEERS_TEST_A", "hello")
+	os.Setenv("MUSKETEERS_TEST_B", "world")
+	defer os.Unsetenv("MUSKETEERS_TEST_A")
+	defer os.Unsetenv("MUSKETEERS_TEST_B")
+	os.Unsetenv("MUSKETEERS_TEST_MISSING")
+
+	fileDir := t.TempDir()
+	filePath := filepath.Join(fileDir, "message.txt")
+	assert.NoError(t, os.WriteFile(filePath, []byte("from a file\n"), 0o600))
+
+	os.Setenv("MUSKETEERS_TEST_ENV_SCHEME", "env scheme value")
+	defer os.Unsetenv("MUSKETEERS_TEST_ENV_SCHEME")
+
+	resolvetests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"plain string", "just text", "just text"},
+		{"dollar escape", "price is $$5", "price is $5"},
+		{"braced var", "${MUSKETEERS_TEST_A}", "hello"},
+		{"bare var", "$MUSKETEERS_TEST_A", "hello"},
+		{"nested braced vars", "${MUSKETEERS_TEST_A} ${MUSKETEERS_TEST_B}", "hello world"},
+		{"missing var", "${MUSKETEERS_TEST_MISSING}", ""},
+		{"file scheme", "file://" + filePath, "from a file"},
+		{"env scheme", "env://MUSKETEERS_TEST_ENV_SCHEME", "env scheme value"},
+	}
+
+	for _, rt := range resolvetests {
+		t.Run(rt.name, func(t *testing.T) {
+			// when
+			resolved, err := resolveMessage(rt.message)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, rt.expected, resolved)
+		})
+	}
+}
+
+func TestResolveMessageFileSchemeMissingFile(t *testing.T) {
+	// when
+	_, err := resolveMessage("file://" + filepath.Join(t.TempDir(), "does-not-exist.txt"))
+
+	// then
+	assert.Error(t, err)
+}
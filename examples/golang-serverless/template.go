@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	messageTemplateEnvVarName   = "ECHO_MESSAGE_TEMPLATE"
+	templateEnvWhitelistVarName = "ECHO_MESSAGE_TEMPLATE_ENV_WHITELIST"
+	responseHeadersEnvVarName   = "ECHO_RESPONSE_HEADERS"
+	statusCodeEnvVarName        = "ECHO_STATUS_CODE"
+)
+
+// templateData is the root context text/template renders ECHO_MESSAGE_TEMPLATE with.
+type templateData struct {
+	Request events.APIGatewayProxyRequest
+	Env     map[string]string
+}
+
+// renderMessageTemplate renders tmpl against request and the whitelisted
+// env vars from envMap, so templates can reference both .Request and .Env.
+func renderMessageTemplate(tmpl string, request events.APIGatewayProxyRequest) (string, error) {
+	t, err := template.New("echoMessage").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("renderMessageTemplate: parsing template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	data := templateData{
+		Request: request,
+		Env:     envMap(),
+	}
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("renderMessageTemplate: executing template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// envMap returns the env vars named in ECHO_MESSAGE_TEMPLATE_ENV_WHITELIST
+// (a comma-separated list) as a map, for use as .Env inside message
+// templates. Templates are user-controlled input, and the process
+// environment can carry AWS credentials and other secrets, so only
+// explicitly whitelisted names are ever exposed; an unset or empty
+// whitelist yields an empty map.
+func envMap() map[string]string {
+	env := make(map[string]string)
+	for _, name := range whitelistedEnvNames() {
+		if value, ok := os.LookupEnv(name); ok {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// whitelistedEnvNames parses ECHO_MESSAGE_TEMPLATE_ENV_WHITELIST into the
+// list of env var names it permits.
+func whitelistedEnvNames() []string {
+	raw := os.Getenv(templateEnvWhitelistVarName)
+	if raw == "" {
+		return nil
+	}
+
+	var names []string
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// responseHeaders parses ECHO_RESPONSE_HEADERS, a comma-separated list of
+// K=V pairs, into a header map. An empty env var yields a nil map.
+func responseHeaders() map[string]string {
+	raw := os.Getenv(responseHeadersEnvVarName)
+	if raw == "" {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// statusCode returns the ECHO_STATUS_CODE override if set and valid,
+// otherwise it falls back to defaultCode.
+func statusCode(defaultCode int) int {
+	raw := os.Getenv(statusCodeEnvVarName)
+	if raw == "" {
+		return defaultCode
+	}
+
+	code, err := strconv.Atoi(raw)
+	if err != nil || http.StatusText(code) == "" {
+		return defaultCode
+	}
+	return code
+}
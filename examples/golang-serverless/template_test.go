@@ -0,0 +1,127 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func clearTemplateEnvVars() {
+	os.Unsetenv(messageTemplateEnvVarName)
+	os.Unsetenv(templateEnvWhitelistVarName)
+	os.Unsetenv(responseHeadersEnvVarName)
+	os.Unsetenv(statusCodeEnvVarName)
+}
+
+func TestEchoMessageTemplate(t *testing.T) {
+	defer clearTemplateEnvVars()
+
+	// given
+	os.Setenv(messageTemplateEnvVarName, "hello {{ .Request.QueryStringParameters.name }}")
+	evt := events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"name": "musketeer"},
+	}
+
+	// when
+	response, err := Echo(nil, evt)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "hello musketeer", response.Body)
+}
+
+func TestEchoMessageTemplateEnvWhitelist(t *testing.T) {
+	defer clearTemplateEnvVars()
+
+	// given
+	os.Setenv("MUSKETEERS_TEST_WHITELISTED", "visible")
+	os.Setenv("MUSKETEERS_TEST_NOT_WHITELISTED", "secret")
+	defer os.Unsetenv("MUSKETEERS_TEST_WHITELISTED")
+	defer os.Unsetenv("MUSKETEERS_TEST_NOT_WHITELISTED")
+
+	os.Setenv(templateEnvWhitelistVarName, "MUSKETEERS_TEST_WHITELISTED")
+	os.Setenv(messageTemplateEnvVarName, "{{ .Env.MUSKETEERS_TEST_WHITELISTED }}|{{ index .Env \"MUSKETEERS_TEST_NOT_WHITELISTED\" }}")
+
+	// when
+	response, err := Echo(nil, events.APIGatewayProxyRequest{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "visible|", response.Body)
+}
+
+func TestEchoMessageTemplateEnvWhitelistUnsetYieldsNoEnv(t *testing.T) {
+	defer clearTemplateEnvVars()
+
+	// given
+	os.Setenv("MUSKETEERS_TEST_NOT_WHITELISTED", "secret")
+	defer os.Unsetenv("MUSKETEERS_TEST_NOT_WHITELISTED")
+
+	os.Unsetenv(templateEnvWhitelistVarName)
+	os.Setenv(messageTemplateEnvVarName, "[{{ index .Env \"MUSKETEERS_TEST_NOT_WHITELISTED\" }}]")
+
+	// when
+	response, err := Echo(nil, events.APIGatewayProxyRequest{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", response.Body)
+}
+
+func TestEchoResponseHeaders(t *testing.T) {
+	defer clearTemplateEnvVars()
+
+	// given
+	os.Setenv(messageTemplateEnvVarName, "hello")
+	os.Setenv(responseHeadersEnvVarName, "X-Musketeer=Athos,Content-Type=text/plain")
+
+	// when
+	response, err := Echo(nil, events.APIGatewayProxyRequest{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "Athos", response.Headers["X-Musketeer"])
+	assert.Equal(t, "text/plain", response.Headers["Content-Type"])
+}
+
+func TestEchoStatusCodeOverride(t *testing.T) {
+	defer clearTemplateEnvVars()
+
+	// given
+	os.Setenv(messageTemplateEnvVarName, "hello")
+	os.Setenv(statusCodeEnvVarName, "201")
+
+	// when
+	response, err := Echo(nil, events.APIGatewayProxyRequest{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 201, response.StatusCode)
+}
+
+func TestEchoMalformedTemplate(t *testing.T) {
+	templatetests := []struct {
+		name string
+		tmpl string
+	}{
+		{"unclosed action", "hello {{ .Request.QueryStringParameters.name "},
+		{"unknown field", "hello {{ .Request.Nope }}"},
+	}
+
+	for _, tt := range templatetests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer clearTemplateEnvVars()
+
+			// given
+			os.Setenv(messageTemplateEnvVarName, tt.tmpl)
+
+			// when
+			_, err := Echo(nil, events.APIGatewayProxyRequest{})
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}